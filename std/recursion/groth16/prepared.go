@@ -0,0 +1,114 @@
+package groth16
+
+import (
+	"fmt"
+
+	"github.com/consensys/gnark/backend/groth16"
+	"github.com/consensys/gnark/std/algebra"
+)
+
+// FixedQPairing is satisfied by pairing gadgets that can precompute the line
+// coefficients for a G2 point that stays constant across many pairing
+// checks, and reuse them through [FixedQPairing.PairFixedQ] instead of
+// re-evaluating those lines on every call.
+//
+// No pairing gadget in this module implements FixedQPairing yet; adding line
+// precomputation to a concrete gadget (e.g. sw_bn254) is a separate change.
+// Until then this interface, [PreparedVerifyingKey] and [AssertProofPrepared]
+// are wiring with no fq implementation to plug into and no realized
+// constraint-count saving.
+type FixedQPairing[G1El algebra.G1ElementT, G2El algebra.G2ElementT, GtEl algebra.GtElementT, LinesEl any] interface {
+	algebra.Pairing[G1El, G2El, GtEl]
+
+	// ComputeLines precomputes the pairing line coefficients for a G2 point
+	// that is going to be reused as a fixed input to [PairFixedQ].
+	ComputeLines(Q *G2El) (LinesEl, error)
+	// PairFixedQ computes the product of the pairings of P[i] with the fixed
+	// G2 points whose precomputed lines are lines[i], times the pairing of
+	// dynP with dynQ (which still pays for a full Miller loop line
+	// evaluation), and applies the final exponentiation.
+	PairFixedQ(P []*G1El, lines []LinesEl, dynP *G1El, dynQ *G2El) (*GtEl, error)
+}
+
+// PreparedVerifyingKey is a [VerifyingKey] with GammaNeg and DeltaNeg
+// replaced by their precomputed pairing line coefficients. Use
+// [PrepareVerifyingKey] or [ValueOfPreparedVerifyingKey] to build one, and
+// [AssertProofPrepared] to verify proofs against it.
+type PreparedVerifyingKey[G1El algebra.G1ElementT, G2El algebra.G2ElementT, GtEl algebra.GtElementT, LinesEl any] struct {
+	E  GtEl
+	G1 struct {
+		K     []G1El
+		Alpha G1El
+	}
+	G2 struct {
+		// GammaNegLines and DeltaNegLines are the precomputed pairing lines
+		// for GammaNeg and DeltaNeg, replacing the raw G2 elements carried by
+		// [VerifyingKey].
+		GammaNegLines, DeltaNegLines LinesEl
+		Beta                         G2El
+		CommitmentKeys               []CommitmentKey[G2El]
+	}
+}
+
+// PrepareVerifyingKey precomputes the pairing line coefficients for
+// vk.G2.GammaNeg and vk.G2.DeltaNeg using fq, leaving the rest of vk
+// untouched. Use this when vk is already available as a circuit witness; to
+// build a [PreparedVerifyingKey] straight from a native verifying key, use
+// [ValueOfPreparedVerifyingKey].
+func PrepareVerifyingKey[G1El algebra.G1ElementT, G2El algebra.G2ElementT, GtEl algebra.GtElementT, LinesEl any](fq FixedQPairing[G1El, G2El, GtEl, LinesEl], vk VerifyingKey[G1El, G2El, GtEl]) (PreparedVerifyingKey[G1El, G2El, GtEl, LinesEl], error) {
+	var ret PreparedVerifyingKey[G1El, G2El, GtEl, LinesEl]
+	ret.E = vk.E
+	ret.G1.K = vk.G1.K
+	ret.G1.Alpha = vk.G1.Alpha
+	ret.G2.Beta = vk.G2.Beta
+	ret.G2.CommitmentKeys = vk.G2.CommitmentKeys
+	gammaLines, err := fq.ComputeLines(&vk.G2.GammaNeg)
+	if err != nil {
+		return ret, fmt.Errorf("precompute gamma lines: %w", err)
+	}
+	deltaLines, err := fq.ComputeLines(&vk.G2.DeltaNeg)
+	if err != nil {
+		return ret, fmt.Errorf("precompute delta lines: %w", err)
+	}
+	ret.G2.GammaNegLines = gammaLines
+	ret.G2.DeltaNegLines = deltaLines
+	return ret, nil
+}
+
+// ValueOfPreparedVerifyingKey initializes a [PreparedVerifyingKey] witness
+// straight from the native Groth16 verifying key, precomputing the pairing
+// lines for GammaNeg and DeltaNeg using fq. It returns an error if there is a
+// mismatch between the type parameters and the provided native verifying
+// key.
+func ValueOfPreparedVerifyingKey[G1El algebra.G1ElementT, G2El algebra.G2ElementT, GtEl algebra.GtElementT, LinesEl any](fq FixedQPairing[G1El, G2El, GtEl, LinesEl], vk groth16.VerifyingKey) (PreparedVerifyingKey[G1El, G2El, GtEl, LinesEl], error) {
+	inner, err := ValueOfVerifyingKey[G1El, G2El, GtEl](vk)
+	if err != nil {
+		return PreparedVerifyingKey[G1El, G2El, GtEl, LinesEl]{}, fmt.Errorf("verifying key: %w", err)
+	}
+	return PrepareVerifyingKey[G1El, G2El, GtEl, LinesEl](fq, inner)
+}
+
+// AssertProofPrepared behaves like [Verifier.AssertProof] but takes a
+// [PreparedVerifyingKey]: the two fixed G2 inputs GammaNeg and DeltaNeg are
+// folded in using their precomputed lines through fq.PairFixedQ, so only
+// proof.Bs pays for a full Miller loop line evaluation — once some fq
+// implements that; see the caveat on [FixedQPairing].
+//
+// This is a standalone function, not a method on [Verifier], because
+// LinesEl is not among Verifier's type parameters and Go does not allow a
+// method to introduce additional type parameters of its own.
+func AssertProofPrepared[S algebra.ScalarT, G1El algebra.G1ElementT, G2El algebra.G2ElementT, GtEl algebra.GtElementT, LinesEl any](v *Verifier[S, G1El, G2El, GtEl], fq FixedQPairing[G1El, G2El, GtEl, LinesEl], vk PreparedVerifyingKey[G1El, G2El, GtEl, LinesEl], proof Proof[G1El, G2El], witness Witness[S]) error {
+	unprepared := VerifyingKey[G1El, G2El, GtEl]{G1: vk.G1}
+	unprepared.G2.Beta = vk.G2.Beta
+	unprepared.G2.CommitmentKeys = vk.G2.CommitmentKeys
+	kSum, err := v.foldPublicInputs(unprepared, proof, witness)
+	if err != nil {
+		return err
+	}
+	pairing, err := fq.PairFixedQ([]*G1El{kSum, &proof.Krs}, []LinesEl{vk.G2.GammaNegLines, vk.G2.DeltaNegLines}, &proof.Ar, &proof.Bs)
+	if err != nil {
+		return fmt.Errorf("pairing: %w", err)
+	}
+	v.pairing.AssertIsEqual(pairing, &vk.E)
+	return v.assertCommitmentPok(unprepared, proof)
+}
@@ -0,0 +1,88 @@
+package groth16_test
+
+import (
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/frontend/cs/r1cs"
+	"github.com/consensys/gnark/std/algebra"
+	"github.com/consensys/gnark/std/algebra/emulated/sw_bn254"
+	"github.com/consensys/gnark/std/math/emulated"
+	"github.com/consensys/gnark/std/math/emulated/emparams"
+	stdgroth16 "github.com/consensys/gnark/std/recursion/groth16"
+)
+
+// fakeFixedQPairing adapts any [algebra.Pairing] into a
+// [stdgroth16.FixedQPairing] by treating the G2 element itself as its own
+// "precomputed lines" and falling back to a plain Miller loop in
+// PairFixedQ. No pairing gadget in this module implements
+// [stdgroth16.FixedQPairing] for real (see its doc comment), so this type
+// only lets the compile test below exercise the generics and wiring of
+// [stdgroth16.PrepareVerifyingKey] and [stdgroth16.AssertProofPrepared]; it
+// does not demonstrate, and is not meant to demonstrate, any constraint-count
+// saving.
+type fakeFixedQPairing struct {
+	algebra.Pairing[sw_bn254.G1Affine, sw_bn254.G2Affine, sw_bn254.GTEl]
+}
+
+func (f fakeFixedQPairing) ComputeLines(Q *sw_bn254.G2Affine) (sw_bn254.G2Affine, error) {
+	return *Q, nil
+}
+
+func (f fakeFixedQPairing) PairFixedQ(P []*sw_bn254.G1Affine, lines []sw_bn254.G2Affine, dynP *sw_bn254.G1Affine, dynQ *sw_bn254.G2Affine) (*sw_bn254.GTEl, error) {
+	g1 := append(append([]*sw_bn254.G1Affine{}, P...), dynP)
+	g2 := make([]*sw_bn254.G2Affine, len(lines), len(lines)+1)
+	for i := range lines {
+		g2[i] = &lines[i]
+	}
+	g2 = append(g2, dynQ)
+	return f.Pair(g1, g2)
+}
+
+// preparedCircuit wires up [stdgroth16.PrepareVerifyingKey] and
+// [stdgroth16.AssertProofPrepared] against a placeholder verifying key and
+// proof. It exists to catch the exact bug this package shipped once
+// already: AssertProofPrepared tried to be a method introducing its own
+// type parameter, which Go does not allow and which only a build (or a
+// test that forces compilation) catches.
+type preparedCircuit struct {
+	NbPublic int
+}
+
+func (c *preparedCircuit) Define(api frontend.API) error {
+	curve, err := algebra.GetCurve[emulated.Element[emparams.BN254Fr], sw_bn254.G1Affine](api)
+	if err != nil {
+		return err
+	}
+	pairing, err := algebra.GetPairing[sw_bn254.G1Affine, sw_bn254.G2Affine, sw_bn254.GTEl](api)
+	if err != nil {
+		return err
+	}
+	fq := fakeFixedQPairing{Pairing: pairing}
+	verifier := stdgroth16.NewVerifier[emulated.Element[emparams.BN254Fr]](curve, pairing, api)
+
+	var vk stdgroth16.VerifyingKey[sw_bn254.G1Affine, sw_bn254.G2Affine, sw_bn254.GTEl]
+	vk.G1.K = make([]sw_bn254.G1Affine, c.NbPublic+1)
+
+	preparedVk, err := stdgroth16.PrepareVerifyingKey[sw_bn254.G1Affine, sw_bn254.G2Affine, sw_bn254.GTEl, sw_bn254.G2Affine](fq, vk)
+	if err != nil {
+		return err
+	}
+
+	var proof stdgroth16.Proof[sw_bn254.G1Affine, sw_bn254.G2Affine]
+	witness := stdgroth16.Witness[emulated.Element[emparams.BN254Fr]]{
+		Public: make([]emulated.Element[emparams.BN254Fr], c.NbPublic),
+	}
+
+	return stdgroth16.AssertProofPrepared(verifier, fq, preparedVk, proof, witness)
+}
+
+// TestAssertProofPreparedCompile checks that PrepareVerifyingKey and
+// AssertProofPrepared build a constraint system together.
+func TestAssertProofPreparedCompile(t *testing.T) {
+	circuit := &preparedCircuit{NbPublic: 2}
+	if _, err := frontend.Compile(ecc.BN254.ScalarField(), r1cs.NewBuilder, circuit); err != nil {
+		t.Fatalf("compile: %v", err)
+	}
+}
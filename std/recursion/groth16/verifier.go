@@ -18,11 +18,13 @@ import (
 	groth16backend_bn254 "github.com/consensys/gnark/backend/groth16/bn254"
 	"github.com/consensys/gnark/backend/witness"
 	"github.com/consensys/gnark/constraint"
+	"github.com/consensys/gnark/frontend"
 	"github.com/consensys/gnark/std/algebra"
 	"github.com/consensys/gnark/std/algebra/emulated/sw_bls12381"
 	"github.com/consensys/gnark/std/algebra/emulated/sw_bn254"
 	"github.com/consensys/gnark/std/algebra/native/sw_bls12377"
 	"github.com/consensys/gnark/std/algebra/native/sw_bls24315"
+	"github.com/consensys/gnark/std/hash/mimc"
 	"github.com/consensys/gnark/std/math/emulated"
 	"github.com/consensys/gnark/std/math/emulated/emparams"
 )
@@ -32,6 +34,13 @@ import (
 type Proof[G1El algebra.G1ElementT, G2El algebra.G2ElementT] struct {
 	Ar, Krs G1El
 	Bs      G2El
+
+	// Commitments are the Pedersen commitments to the wires committed to by
+	// the prover (one per call to api.Commit in the inner circuit). It is
+	// empty if the inner circuit does not use commitments.
+	Commitments []G1El
+	// CommitmentPok is the proof of knowledge of the opening of Commitments.
+	CommitmentPok G1El
 }
 
 // ValueOfProof returns the typed witness of the native proof. It returns an
@@ -48,6 +57,11 @@ func ValueOfProof[G1El algebra.G1ElementT, G2El algebra.G2ElementT](proof groth1
 		ar.Ar = sw_bn254.NewG1Affine(tProof.Ar)
 		ar.Krs = sw_bn254.NewG1Affine(tProof.Krs)
 		ar.Bs = sw_bn254.NewG2Affine(tProof.Bs)
+		ar.Commitments = make([]sw_bn254.G1Affine, len(tProof.Commitments))
+		for i := range ar.Commitments {
+			ar.Commitments[i] = sw_bn254.NewG1Affine(tProof.Commitments[i])
+		}
+		ar.CommitmentPok = sw_bn254.NewG1Affine(tProof.CommitmentPok)
 	case *Proof[sw_bls12377.G1Affine, sw_bls12377.G2Affine]:
 		tProof, ok := proof.(*groth16backend_bls12377.Proof)
 		if !ok {
@@ -56,6 +70,11 @@ func ValueOfProof[G1El algebra.G1ElementT, G2El algebra.G2ElementT](proof groth1
 		ar.Ar = sw_bls12377.NewG1Affine(tProof.Ar)
 		ar.Krs = sw_bls12377.NewG1Affine(tProof.Krs)
 		ar.Bs = sw_bls12377.NewG2Affine(tProof.Bs)
+		ar.Commitments = make([]sw_bls12377.G1Affine, len(tProof.Commitments))
+		for i := range ar.Commitments {
+			ar.Commitments[i] = sw_bls12377.NewG1Affine(tProof.Commitments[i])
+		}
+		ar.CommitmentPok = sw_bls12377.NewG1Affine(tProof.CommitmentPok)
 	case *Proof[sw_bls12381.G1Affine, sw_bls12381.G2Affine]:
 		tProof, ok := proof.(*groth16backend_bls12381.Proof)
 		if !ok {
@@ -64,6 +83,11 @@ func ValueOfProof[G1El algebra.G1ElementT, G2El algebra.G2ElementT](proof groth1
 		ar.Ar = sw_bls12381.NewG1Affine(tProof.Ar)
 		ar.Krs = sw_bls12381.NewG1Affine(tProof.Krs)
 		ar.Bs = sw_bls12381.NewG2Affine(tProof.Bs)
+		ar.Commitments = make([]sw_bls12381.G1Affine, len(tProof.Commitments))
+		for i := range ar.Commitments {
+			ar.Commitments[i] = sw_bls12381.NewG1Affine(tProof.Commitments[i])
+		}
+		ar.CommitmentPok = sw_bls12381.NewG1Affine(tProof.CommitmentPok)
 	case *Proof[sw_bls24315.G1Affine, sw_bls24315.G2Affine]:
 		tProof, ok := proof.(*groth16backend_bls24315.Proof)
 		if !ok {
@@ -72,19 +96,50 @@ func ValueOfProof[G1El algebra.G1ElementT, G2El algebra.G2ElementT](proof groth1
 		ar.Ar = sw_bls24315.NewG1Affine(tProof.Ar)
 		ar.Krs = sw_bls24315.NewG1Affine(tProof.Krs)
 		ar.Bs = sw_bls24315.NewG2Affine(tProof.Bs)
+		ar.Commitments = make([]sw_bls24315.G1Affine, len(tProof.Commitments))
+		for i := range ar.Commitments {
+			ar.Commitments[i] = sw_bls24315.NewG1Affine(tProof.Commitments[i])
+		}
+		ar.CommitmentPok = sw_bls24315.NewG1Affine(tProof.CommitmentPok)
 	default:
 		return ret, fmt.Errorf("unknown parametric type combination")
 	}
 	return ret, nil
 }
 
+// CommitmentKey is the verifying-key material the native Groth16 backend
+// attaches to a single Pedersen commitment (one per call to api.Commit in the
+// inner circuit).
+type CommitmentKey[G2El algebra.G2ElementT] struct {
+	// G is the basis the commitment is paired against.
+	G G2El
+	// GSigmaNeg is the negation of the generator used to verify the proof of
+	// knowledge of the commitment opening.
+	GSigmaNeg G2El
+}
+
 // VerifyingKey is a typed Groth16 verifying key for checking SNARK proofs. For
 // witness creation use the method [ValueOfVerifyingKey] and for stub
 // placeholder use [PlaceholderVerifyingKey].
 type VerifyingKey[G1El algebra.G1ElementT, G2El algebra.G2ElementT, GtEl algebra.GtElementT] struct {
-	E  GtEl
-	G1 struct{ K []G1El }
-	G2 struct{ GammaNeg, DeltaNeg G2El }
+	// E is the precomputed e(Alpha, Beta), used by the cheap single-proof
+	// path in [Verifier.AssertProof].
+	E GtEl
+	G1 struct {
+		K []G1El
+		// Alpha is kept alongside the precomputed E so that
+		// [Verifier.AssertProofs] can fold the Σρⱼ·e(Alpha,Beta) term of a
+		// batch into a single extra pairing instead of exponentiating E.
+		Alpha G1El
+	}
+	G2 struct {
+		GammaNeg, DeltaNeg G2El
+		Beta               G2El
+		// CommitmentKeys holds one entry per Pedersen commitment used by the
+		// inner circuit. It is empty if the inner circuit does not use
+		// commitments.
+		CommitmentKeys []CommitmentKey[G2El]
+	}
 }
 
 // PlaceholderVerifyingKey returns an empty verifying key for a given compiled
@@ -92,11 +147,10 @@ type VerifyingKey[G1El algebra.G1ElementT, G2El algebra.G2ElementT, GtEl algebra
 // public inputs and commitments used, this method allocates sufficient space
 // regardless of the actual verifying key.
 func PlaceholderVerifyingKey[G1El algebra.G1ElementT, G2El algebra.G2ElementT, GtEl algebra.GtElementT](ccs constraint.ConstraintSystem) VerifyingKey[G1El, G2El, GtEl] {
-	return VerifyingKey[G1El, G2El, GtEl]{
-		G1: struct{ K []G1El }{
-			K: make([]G1El, ccs.GetNbPublicVariables()),
-		},
-	}
+	var ret VerifyingKey[G1El, G2El, GtEl]
+	ret.G1.K = make([]G1El, ccs.GetNbPublicVariables()+ccs.GetNbCommitments())
+	ret.G2.CommitmentKeys = make([]CommitmentKey[G2El], ccs.GetNbCommitments())
+	return ret
 }
 
 // ValueOfVerifyingKey initializes witness from the given Groth16 verifying key.
@@ -125,6 +179,17 @@ func ValueOfVerifyingKey[G1El algebra.G1ElementT, G2El algebra.G2ElementT, GtEl
 		gammaNeg.Neg(&tVk.G2.Gamma)
 		s.G2.DeltaNeg = sw_bn254.NewG2Affine(deltaNeg)
 		s.G2.GammaNeg = sw_bn254.NewG2Affine(gammaNeg)
+		s.G1.Alpha = sw_bn254.NewG1Affine(tVk.G1.Alpha)
+		s.G2.Beta = sw_bn254.NewG2Affine(tVk.G2.Beta)
+		s.G2.CommitmentKeys = make([]CommitmentKey[sw_bn254.G2Affine], len(tVk.CommitmentKeys))
+		for i := range s.G2.CommitmentKeys {
+			var gSigmaNeg bn254.G2Affine
+			gSigmaNeg.Neg(&tVk.CommitmentKeys[i].GSigma)
+			s.G2.CommitmentKeys[i] = CommitmentKey[sw_bn254.G2Affine]{
+				G:         sw_bn254.NewG2Affine(tVk.CommitmentKeys[i].G),
+				GSigmaNeg: sw_bn254.NewG2Affine(gSigmaNeg),
+			}
+		}
 	case *VerifyingKey[sw_bls12377.G1Affine, sw_bls12377.G2Affine, sw_bls12377.GT]:
 		tVk, ok := vk.(*groth16backend_bls12377.VerifyingKey)
 		if !ok {
@@ -145,6 +210,17 @@ func ValueOfVerifyingKey[G1El algebra.G1ElementT, G2El algebra.G2ElementT, GtEl
 		gammaNeg.Neg(&tVk.G2.Gamma)
 		s.G2.DeltaNeg = sw_bls12377.NewG2Affine(deltaNeg)
 		s.G2.GammaNeg = sw_bls12377.NewG2Affine(gammaNeg)
+		s.G1.Alpha = sw_bls12377.NewG1Affine(tVk.G1.Alpha)
+		s.G2.Beta = sw_bls12377.NewG2Affine(tVk.G2.Beta)
+		s.G2.CommitmentKeys = make([]CommitmentKey[sw_bls12377.G2Affine], len(tVk.CommitmentKeys))
+		for i := range s.G2.CommitmentKeys {
+			var gSigmaNeg bls12377.G2Affine
+			gSigmaNeg.Neg(&tVk.CommitmentKeys[i].GSigma)
+			s.G2.CommitmentKeys[i] = CommitmentKey[sw_bls12377.G2Affine]{
+				G:         sw_bls12377.NewG2Affine(tVk.CommitmentKeys[i].G),
+				GSigmaNeg: sw_bls12377.NewG2Affine(gSigmaNeg),
+			}
+		}
 	case *VerifyingKey[sw_bls12381.G1Affine, sw_bls12381.G2Affine, sw_bls12381.GTEl]:
 		tVk, ok := vk.(*groth16backend_bls12381.VerifyingKey)
 		if !ok {
@@ -165,6 +241,17 @@ func ValueOfVerifyingKey[G1El algebra.G1ElementT, G2El algebra.G2ElementT, GtEl
 		gammaNeg.Neg(&tVk.G2.Gamma)
 		s.G2.DeltaNeg = sw_bls12381.NewG2Affine(deltaNeg)
 		s.G2.GammaNeg = sw_bls12381.NewG2Affine(gammaNeg)
+		s.G1.Alpha = sw_bls12381.NewG1Affine(tVk.G1.Alpha)
+		s.G2.Beta = sw_bls12381.NewG2Affine(tVk.G2.Beta)
+		s.G2.CommitmentKeys = make([]CommitmentKey[sw_bls12381.G2Affine], len(tVk.CommitmentKeys))
+		for i := range s.G2.CommitmentKeys {
+			var gSigmaNeg bls12381.G2Affine
+			gSigmaNeg.Neg(&tVk.CommitmentKeys[i].GSigma)
+			s.G2.CommitmentKeys[i] = CommitmentKey[sw_bls12381.G2Affine]{
+				G:         sw_bls12381.NewG2Affine(tVk.CommitmentKeys[i].G),
+				GSigmaNeg: sw_bls12381.NewG2Affine(gSigmaNeg),
+			}
+		}
 	case *VerifyingKey[sw_bls24315.G1Affine, sw_bls24315.G2Affine, sw_bls24315.GT]:
 		tVk, ok := vk.(*groth16backend_bls24315.VerifyingKey)
 		if !ok {
@@ -185,6 +272,17 @@ func ValueOfVerifyingKey[G1El algebra.G1ElementT, G2El algebra.G2ElementT, GtEl
 		gammaNeg.Neg(&tVk.G2.Gamma)
 		s.G2.DeltaNeg = sw_bls24315.NewG2Affine(deltaNeg)
 		s.G2.GammaNeg = sw_bls24315.NewG2Affine(gammaNeg)
+		s.G1.Alpha = sw_bls24315.NewG1Affine(tVk.G1.Alpha)
+		s.G2.Beta = sw_bls24315.NewG2Affine(tVk.G2.Beta)
+		s.G2.CommitmentKeys = make([]CommitmentKey[sw_bls24315.G2Affine], len(tVk.CommitmentKeys))
+		for i := range s.G2.CommitmentKeys {
+			var gSigmaNeg bls24315.G2Affine
+			gSigmaNeg.Neg(&tVk.CommitmentKeys[i].GSigma)
+			s.G2.CommitmentKeys[i] = CommitmentKey[sw_bls24315.G2Affine]{
+				G:         sw_bls24315.NewG2Affine(tVk.CommitmentKeys[i].G),
+				GSigmaNeg: sw_bls24315.NewG2Affine(gSigmaNeg),
+			}
+		}
 	default:
 		return ret, fmt.Errorf("unknown parametric type combination")
 	}
@@ -264,40 +362,396 @@ func ValueOfWitness[S algebra.ScalarT, G1 algebra.G1ElementT](w witness.Witness)
 
 // Verifier verifies Groth16 proofs.
 type Verifier[S algebra.ScalarT, G1El algebra.G1ElementT, G2El algebra.G2ElementT, GtEl algebra.GtElementT] struct {
+	api     frontend.API
 	curve   algebra.Curve[S, G1El]
 	pairing algebra.Pairing[G1El, G2El, GtEl]
 }
 
 // NewVerifier returns a new [Verifier] instance using the curve and pairing
 // interfaces. Use methods [algebra.GetCurve] and [algebra.GetPairing] to
-// initialize the instances.
-func NewVerifier[S algebra.ScalarT, G1El algebra.G1ElementT, G2El algebra.G2ElementT, GtEl algebra.GtElementT](curve algebra.Curve[S, G1El], pairing algebra.Pairing[G1El, G2El, GtEl]) *Verifier[S, G1El, G2El, GtEl] {
-	return &Verifier[S, G1El, G2El, GtEl]{
+// initialize the instances. api is optional and only needed to bind the
+// Fiat-Shamir transcript used by [Verifier.AssertProof] when the proof
+// carries Pedersen commitments, and by [Verifier.AssertProofs]; omit it when
+// verifying commitment-free proofs one at a time.
+func NewVerifier[S algebra.ScalarT, G1El algebra.G1ElementT, G2El algebra.G2ElementT, GtEl algebra.GtElementT](curve algebra.Curve[S, G1El], pairing algebra.Pairing[G1El, G2El, GtEl], api ...frontend.API) *Verifier[S, G1El, G2El, GtEl] {
+	v := &Verifier[S, G1El, G2El, GtEl]{
 		curve:   curve,
 		pairing: pairing,
 	}
+	if len(api) > 0 {
+		v.api = api[0]
+	}
+	return v
+}
+
+// requireAPI returns an error if v was constructed without a frontend.API,
+// which is needed to bind the Fiat-Shamir transcript.
+func (v *Verifier[S, G1El, G2El, GtEl]) requireAPI() error {
+	if v.api == nil {
+		return fmt.Errorf("verifier constructed without a frontend.API; pass one to NewVerifier")
+	}
+	return nil
 }
 
 // AssertProof asserts that the SNARK proof holds for the given witness and
-// verifying key.
+// verifying key. If the proof carries Pedersen commitments, it additionally
+// recomputes the Fiat-Shamir challenges the native backend folded into the
+// public inputs and checks the accompanying proofs of knowledge.
 func (v *Verifier[S, G1El, G2El, GtEl]) AssertProof(vk VerifyingKey[G1El, G2El, GtEl], proof Proof[G1El, G2El], witness Witness[S]) error {
+	nbCommitments := len(proof.Commitments)
+	if len(vk.G1.K) != len(witness.Public)+nbCommitments+1 {
+		return fmt.Errorf("vk has %d public inputs, but witness has %d and proof has %d commitments", len(vk.G1.K)-1, len(witness.Public), nbCommitments)
+	}
 	inP := make([]*G1El, len(vk.G1.K)-1) // first is for the one wire, we add it manually after MSM
 	for i := range inP {
 		inP[i] = &vk.G1.K[i+1]
 	}
-	inS := make([]*S, len(witness.Public))
-	for i := range inS {
+	inS := make([]*S, len(vk.G1.K)-1)
+	for i := range witness.Public {
 		inS[i] = &witness.Public[i]
 	}
+	challenges := make([]S, nbCommitments)
+	for i := 0; i < nbCommitments; i++ {
+		challenge, err := v.commitmentChallenge(vk.G2.CommitmentKeys[i], proof.Commitments[i])
+		if err != nil {
+			return fmt.Errorf("commitment challenge %d: %w", i, err)
+		}
+		challenges[i] = challenge
+		inS[len(witness.Public)+i] = &challenges[i]
+	}
 	kSum, err := v.curve.MultiScalarMul(inP, inS)
 	if err != nil {
 		return fmt.Errorf("multi scalar mul: %w", err)
 	}
 	kSum = v.curve.Add(kSum, &vk.G1.K[0])
+	for i := range proof.Commitments {
+		kSum = v.curve.Add(kSum, &proof.Commitments[i])
+	}
 	pairing, err := v.pairing.Pair([]*G1El{kSum, &proof.Krs, &proof.Ar}, []*G2El{&vk.G2.GammaNeg, &vk.G2.DeltaNeg, &proof.Bs})
 	if err != nil {
 		return fmt.Errorf("pairing: %w", err)
 	}
 	v.pairing.AssertIsEqual(pairing, &vk.E)
+	return v.assertCommitmentPok(vk, proof)
+}
+
+// assertCommitmentPok checks the proof of knowledge of the opening of
+// proof.Commitments. With a single commitment this is the direct pairing
+// relation e(Commitment, G) = e(CommitmentPok, GSigma) (checked as
+// e(Commitment, G)·e(CommitmentPok, GSigmaNeg) = 1, since GSigmaNeg = -GSigma).
+// With more than one commitment, the single shared proof.CommitmentPok cannot
+// satisfy N independent relations of that shape at once, so relation i is
+// instead weighted by ρⁱ for a Fiat-Shamir challenge ρ — weighting
+// Commitment and CommitmentPok by the same weight leaves each relation's
+// individual pairing terms e(Commitment,G)·e(CommitmentPok,GSigmaNeg)
+// raised to that weight, by bilinearity — and all 2N resulting pairing terms
+// are checked together in one combined call, which is sound as long as a
+// cheating prover cannot predict the weights before committing to its proof.
+func (v *Verifier[S, G1El, G2El, GtEl]) assertCommitmentPok(vk VerifyingKey[G1El, G2El, GtEl], proof Proof[G1El, G2El]) error {
+	switch len(proof.Commitments) {
+	case 0:
+		return nil
+	case 1:
+		if err := v.pairing.PairingCheck([]*G1El{&proof.Commitments[0], &proof.CommitmentPok}, []*G2El{&vk.G2.CommitmentKeys[0].G, &vk.G2.CommitmentKeys[0].GSigmaNeg}); err != nil {
+			return fmt.Errorf("commitment proof of knowledge: %w", err)
+		}
+		return nil
+	default:
+		weights, err := v.commitmentFoldingChallenges(proof.Commitments)
+		if err != nil {
+			return fmt.Errorf("commitment folding challenges: %w", err)
+		}
+		n := len(proof.Commitments)
+		weightedCommitments := make([]G1El, n)
+		weightedPoks := make([]G1El, n)
+		g1 := make([]*G1El, 0, 2*n)
+		g2 := make([]*G2El, 0, 2*n)
+		for i := range proof.Commitments {
+			weightedCommitments[i] = *v.curve.ScalarMul(&proof.Commitments[i], &weights[i])
+			weightedPoks[i] = *v.curve.ScalarMul(&proof.CommitmentPok, &weights[i])
+			g1 = append(g1, &weightedCommitments[i], &weightedPoks[i])
+			g2 = append(g2, &vk.G2.CommitmentKeys[i].G, &vk.G2.CommitmentKeys[i].GSigmaNeg)
+		}
+		if err := v.pairing.PairingCheck(g1, g2); err != nil {
+			return fmt.Errorf("commitment proof of knowledge: %w", err)
+		}
+		return nil
+	}
+}
+
+// commitmentFoldingChallenges derives one Fiat-Shamir weight per commitment,
+// bound to every commitment in the proof, used by
+// [Verifier.assertCommitmentPok] to fold several proof-of-knowledge relations
+// into one.
+func (v *Verifier[S, G1El, G2El, GtEl]) commitmentFoldingChallenges(commitments []G1El) ([]S, error) {
+	if err := v.requireAPI(); err != nil {
+		return nil, err
+	}
+	hsh, err := mimc.NewMiMC(v.api)
+	if err != nil {
+		return nil, fmt.Errorf("new mimc: %w", err)
+	}
+	for i := range commitments {
+		hsh.Write(v.curve.MarshalG1(commitments[i])...)
+	}
+	sum := hsh.Sum()
+	var rho S
+	switch c := any(&rho).(type) {
+	case *frontend.Variable:
+		*c = sum
+	case *emulated.Element[emparams.BN254Fr]:
+		field, err := emulated.NewField[emparams.BN254Fr](v.api)
+		if err != nil {
+			return nil, fmt.Errorf("new field: %w", err)
+		}
+		*c = *field.FromBits(v.api.ToBinary(sum)...)
+	case *emulated.Element[emparams.BLS12381Fr]:
+		field, err := emulated.NewField[emparams.BLS12381Fr](v.api)
+		if err != nil {
+			return nil, fmt.Errorf("new field: %w", err)
+		}
+		*c = *field.FromBits(v.api.ToBinary(sum)...)
+	default:
+		return nil, fmt.Errorf("unknown scalar type")
+	}
+	weights, _, err := v.scalarPowersAndSum(rho, len(commitments))
+	if err != nil {
+		return nil, fmt.Errorf("weights: %w", err)
+	}
+	return weights, nil
+}
+
+// commitmentChallenge recomputes the Fiat-Shamir challenge the native backend
+// derives for a single Pedersen commitment, by hashing the commitment through
+// MiMC, mirroring the BSB22 commitment scheme used by the prover. ck is not
+// part of the hash.
+func (v *Verifier[S, G1El, G2El, GtEl]) commitmentChallenge(ck CommitmentKey[G2El], commitment G1El) (S, error) {
+	if err := v.requireAPI(); err != nil {
+		return *new(S), err
+	}
+	var challenge S
+	hsh, err := mimc.NewMiMC(v.api)
+	if err != nil {
+		return challenge, fmt.Errorf("new mimc: %w", err)
+	}
+	hsh.Write(v.curve.MarshalG1(commitment)...)
+	sum := hsh.Sum()
+	switch c := any(&challenge).(type) {
+	case *frontend.Variable:
+		*c = sum
+	case *emulated.Element[emparams.BN254Fr]:
+		field, err := emulated.NewField[emparams.BN254Fr](v.api)
+		if err != nil {
+			return challenge, fmt.Errorf("new field: %w", err)
+		}
+		*c = *field.FromBits(v.api.ToBinary(sum)...)
+	case *emulated.Element[emparams.BLS12381Fr]:
+		field, err := emulated.NewField[emparams.BLS12381Fr](v.api)
+		if err != nil {
+			return challenge, fmt.Errorf("new field: %w", err)
+		}
+		*c = *field.FromBits(v.api.ToBinary(sum)...)
+	default:
+		return challenge, fmt.Errorf("unknown scalar type")
+	}
+	return challenge, nil
+}
+
+// AssertProofs asserts that all of proofs hold against the same vk for their
+// respective witnesses. It samples a Fiat-Shamir challenge ρ bound to every
+// public input and to each proof's Ar, Krs and Commitments (Bs and
+// CommitmentPok are not hashed), and folds the n otherwise-independent checks
+// into a single combined pairing check, at the cost of one extra pairing against
+// vk.G1.Alpha/vk.G2.Beta instead of n.
+func (v *Verifier[S, G1El, G2El, GtEl]) AssertProofs(vk VerifyingKey[G1El, G2El, GtEl], proofs []Proof[G1El, G2El], witnesses []Witness[S]) error {
+	if len(proofs) != len(witnesses) {
+		return fmt.Errorf("got %d proofs but %d witnesses", len(proofs), len(witnesses))
+	}
+	n := len(proofs)
+	if n == 0 {
+		return fmt.Errorf("no proofs to verify")
+	}
+	rho, err := v.proofsChallenge(proofs, witnesses)
+	if err != nil {
+		return fmt.Errorf("proofs challenge: %w", err)
+	}
+	rhoPowers, rhoSum, err := v.scalarPowersAndSum(rho, n)
+	if err != nil {
+		return fmt.Errorf("scalar powers: %w", err)
+	}
+
+	kSums := make([]*G1El, n)
+	krsPtrs := make([]*G1El, n)
+	weightedAr := make([]G1El, n)
+	bsPtrs := make([]*G2El, n)
+	rhoPowerPtrs := make([]*S, n)
+	for j := range proofs {
+		kSum, err := v.foldPublicInputs(vk, proofs[j], witnesses[j])
+		if err != nil {
+			return fmt.Errorf("proof %d: %w", j, err)
+		}
+		kSums[j] = kSum
+		krsPtrs[j] = &proofs[j].Krs
+		bsPtrs[j] = &proofs[j].Bs
+		rhoPowerPtrs[j] = &rhoPowers[j]
+		weightedAr[j] = *v.curve.ScalarMul(&proofs[j].Ar, &rhoPowers[j])
+	}
+	kSumAgg, err := v.curve.MultiScalarMul(kSums, rhoPowerPtrs)
+	if err != nil {
+		return fmt.Errorf("aggregate kSum: %w", err)
+	}
+	krsAgg, err := v.curve.MultiScalarMul(krsPtrs, rhoPowerPtrs)
+	if err != nil {
+		return fmt.Errorf("aggregate Krs: %w", err)
+	}
+	// the Σρⱼ·e(Alpha,Beta) = e(Alpha,Beta)^Σρⱼ term of the batch, folded
+	// into a single extra pairing against -ρSum·Alpha instead of
+	// exponentiating the GT element e(Alpha,Beta).
+	alphaAgg := v.curve.Neg(v.curve.ScalarMul(&vk.G1.Alpha, &rhoSum))
+
+	g1 := []*G1El{kSumAgg, krsAgg, alphaAgg}
+	g2 := []*G2El{&vk.G2.GammaNeg, &vk.G2.DeltaNeg, &vk.G2.Beta}
+	for j := range weightedAr {
+		g1 = append(g1, &weightedAr[j])
+		g2 = append(g2, bsPtrs[j])
+	}
+	if err := v.pairing.PairingCheck(g1, g2); err != nil {
+		return fmt.Errorf("batched pairing check: %w", err)
+	}
+	// The aggregated check above only folds the public-input contribution of
+	// each proof's commitments into its kSumⱼ term; it does not, on its own,
+	// verify that each proof actually knows the opening of its commitments.
+	// Run that check per proof, same as n calls to [Verifier.AssertProof]
+	// would.
+	for j := range proofs {
+		if err := v.assertCommitmentPok(vk, proofs[j]); err != nil {
+			return fmt.Errorf("proof %d: %w", j, err)
+		}
+	}
 	return nil
 }
+
+// proofsChallenge derives a single Fiat-Shamir scalar ρ bound to every
+// witness in the batch and to each proof's Ar, Krs and Commitments, using the
+// same MiMC transcript construction as [Verifier.commitmentChallenge]. The G2
+// element Bs and CommitmentPok of each proof are not hashed.
+func (v *Verifier[S, G1El, G2El, GtEl]) proofsChallenge(proofs []Proof[G1El, G2El], witnesses []Witness[S]) (S, error) {
+	if err := v.requireAPI(); err != nil {
+		return *new(S), err
+	}
+	var challenge S
+	hsh, err := mimc.NewMiMC(v.api)
+	if err != nil {
+		return challenge, fmt.Errorf("new mimc: %w", err)
+	}
+	for j := range proofs {
+		hsh.Write(v.curve.MarshalG1(proofs[j].Ar)...)
+		hsh.Write(v.curve.MarshalG1(proofs[j].Krs)...)
+		for i := range proofs[j].Commitments {
+			hsh.Write(v.curve.MarshalG1(proofs[j].Commitments[i])...)
+		}
+		for i := range witnesses[j].Public {
+			hsh.Write(v.curve.MarshalScalar(witnesses[j].Public[i])...)
+		}
+	}
+	sum := hsh.Sum()
+	switch c := any(&challenge).(type) {
+	case *frontend.Variable:
+		*c = sum
+	case *emulated.Element[emparams.BN254Fr]:
+		field, err := emulated.NewField[emparams.BN254Fr](v.api)
+		if err != nil {
+			return challenge, fmt.Errorf("new field: %w", err)
+		}
+		*c = *field.FromBits(v.api.ToBinary(sum)...)
+	case *emulated.Element[emparams.BLS12381Fr]:
+		field, err := emulated.NewField[emparams.BLS12381Fr](v.api)
+		if err != nil {
+			return challenge, fmt.Errorf("new field: %w", err)
+		}
+		*c = *field.FromBits(v.api.ToBinary(sum)...)
+	default:
+		return challenge, fmt.Errorf("unknown scalar type")
+	}
+	return challenge, nil
+}
+
+// scalarPowersAndSum returns ρ⁰, ..., ρⁿ⁻¹ together with their sum Σρⱼ, using
+// the scalar field arithmetic matching S.
+func (v *Verifier[S, G1El, G2El, GtEl]) scalarPowersAndSum(rho S, n int) ([]S, S, error) {
+	powers := make([]S, n)
+	var sum S
+	switch r := any(&rho).(type) {
+	case *frontend.Variable:
+		acc := frontend.Variable(1)
+		sumAcc := frontend.Variable(0)
+		for i := 0; i < n; i++ {
+			powers[i] = any(acc).(S)
+			sumAcc = v.api.Add(sumAcc, acc)
+			acc = v.api.Mul(acc, *r)
+		}
+		sum = any(sumAcc).(S)
+	case *emulated.Element[emparams.BN254Fr]:
+		field, err := emulated.NewField[emparams.BN254Fr](v.api)
+		if err != nil {
+			return nil, sum, fmt.Errorf("new field: %w", err)
+		}
+		acc := field.One()
+		sumAcc := field.Zero()
+		for i := 0; i < n; i++ {
+			powers[i] = any(*acc).(S)
+			sumAcc = field.Add(sumAcc, acc)
+			acc = field.Mul(acc, r)
+		}
+		sum = any(*sumAcc).(S)
+	case *emulated.Element[emparams.BLS12381Fr]:
+		field, err := emulated.NewField[emparams.BLS12381Fr](v.api)
+		if err != nil {
+			return nil, sum, fmt.Errorf("new field: %w", err)
+		}
+		acc := field.One()
+		sumAcc := field.Zero()
+		for i := 0; i < n; i++ {
+			powers[i] = any(*acc).(S)
+			sumAcc = field.Add(sumAcc, acc)
+			acc = field.Mul(acc, r)
+		}
+		sum = any(*sumAcc).(S)
+	default:
+		return nil, sum, fmt.Errorf("unknown scalar type")
+	}
+	return powers, sum, nil
+}
+
+// foldPublicInputs computes kSum for a single proof the same way
+// [Verifier.AssertProof] does, without yet running the pairing check.
+func (v *Verifier[S, G1El, G2El, GtEl]) foldPublicInputs(vk VerifyingKey[G1El, G2El, GtEl], proof Proof[G1El, G2El], witness Witness[S]) (*G1El, error) {
+	nbCommitments := len(proof.Commitments)
+	if len(vk.G1.K) != len(witness.Public)+nbCommitments+1 {
+		return nil, fmt.Errorf("vk has %d public inputs, but witness has %d and proof has %d commitments", len(vk.G1.K)-1, len(witness.Public), nbCommitments)
+	}
+	inP := make([]*G1El, len(vk.G1.K)-1)
+	for i := range inP {
+		inP[i] = &vk.G1.K[i+1]
+	}
+	inS := make([]*S, len(vk.G1.K)-1)
+	for i := range witness.Public {
+		inS[i] = &witness.Public[i]
+	}
+	for i := 0; i < nbCommitments; i++ {
+		challenge, err := v.commitmentChallenge(vk.G2.CommitmentKeys[i], proof.Commitments[i])
+		if err != nil {
+			return nil, fmt.Errorf("commitment challenge %d: %w", i, err)
+		}
+		inS[len(witness.Public)+i] = &challenge
+	}
+	kSum, err := v.curve.MultiScalarMul(inP, inS)
+	if err != nil {
+		return nil, fmt.Errorf("multi scalar mul: %w", err)
+	}
+	kSum = v.curve.Add(kSum, &vk.G1.K[0])
+	for i := range proof.Commitments {
+		kSum = v.curve.Add(kSum, &proof.Commitments[i])
+	}
+	return kSum, nil
+}
@@ -0,0 +1,148 @@
+package groth16_test
+
+import (
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/backend/groth16"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/frontend/cs/r1cs"
+	"github.com/consensys/gnark/std/algebra"
+	"github.com/consensys/gnark/std/algebra/emulated/sw_bn254"
+	"github.com/consensys/gnark/std/math/emulated"
+	"github.com/consensys/gnark/std/math/emulated/emparams"
+	stdgroth16 "github.com/consensys/gnark/std/recursion/groth16"
+	"github.com/consensys/gnark/test"
+)
+
+// batchCircuit wires up [stdgroth16.Verifier.AssertProofs] against several
+// placeholder proofs sharing one VerifyingKey, to catch wiring mistakes in
+// the aggregated pairing check. The placeholder proofs carry no commitments,
+// so the per-proof commitment proof-of-knowledge check that AssertProofs runs
+// alongside it adds nothing here.
+type batchCircuit struct {
+	NbProofs int
+	NbPublic int
+}
+
+func (c *batchCircuit) Define(api frontend.API) error {
+	curve, err := algebra.GetCurve[emulated.Element[emparams.BN254Fr], sw_bn254.G1Affine](api)
+	if err != nil {
+		return err
+	}
+	pairing, err := algebra.GetPairing[sw_bn254.G1Affine, sw_bn254.G2Affine, sw_bn254.GTEl](api)
+	if err != nil {
+		return err
+	}
+	verifier := stdgroth16.NewVerifier[emulated.Element[emparams.BN254Fr]](curve, pairing, api)
+
+	var vk stdgroth16.VerifyingKey[sw_bn254.G1Affine, sw_bn254.G2Affine, sw_bn254.GTEl]
+	vk.G1.K = make([]sw_bn254.G1Affine, c.NbPublic+1)
+
+	proofs := make([]stdgroth16.Proof[sw_bn254.G1Affine, sw_bn254.G2Affine], c.NbProofs)
+	witnesses := make([]stdgroth16.Witness[emulated.Element[emparams.BN254Fr]], c.NbProofs)
+	for i := range witnesses {
+		witnesses[i].Public = make([]emulated.Element[emparams.BN254Fr], c.NbPublic)
+	}
+
+	return verifier.AssertProofs(vk, proofs, witnesses)
+}
+
+// TestAssertProofsCompile checks that the batched verifier builds a
+// constraint system for more than one proof. TestAssertProofsRoundTrip below
+// exercises it against genuine proofs.
+func TestAssertProofsCompile(t *testing.T) {
+	circuit := &batchCircuit{NbProofs: 3, NbPublic: 2}
+	if _, err := frontend.Compile(ecc.BN254.ScalarField(), r1cs.NewBuilder, circuit); err != nil {
+		t.Fatalf("compile: %v", err)
+	}
+}
+
+// innerMulCircuit asserts A*B == N, so distinct (A, B, N) assignments produce
+// distinct, independently valid native proofs to batch-verify below.
+type innerMulCircuit struct {
+	A, B frontend.Variable
+	N    frontend.Variable `gnark:",public"`
+}
+
+func (c *innerMulCircuit) Define(api frontend.API) error {
+	api.AssertIsEqual(api.Mul(c.A, c.B), c.N)
+	return nil
+}
+
+// outerBatchVerifierCircuit recursively verifies several genuine
+// innerMulCircuit proofs in one go through AssertProofs.
+type outerBatchVerifierCircuit struct {
+	VerifyingKey stdgroth16.VerifyingKey[sw_bn254.G1Affine, sw_bn254.G2Affine, sw_bn254.GTEl]
+	Proofs       []stdgroth16.Proof[sw_bn254.G1Affine, sw_bn254.G2Affine]
+	Witnesses    []stdgroth16.Witness[emulated.Element[emparams.BN254Fr]]
+}
+
+func (c *outerBatchVerifierCircuit) Define(api frontend.API) error {
+	curve, err := algebra.GetCurve[emulated.Element[emparams.BN254Fr], sw_bn254.G1Affine](api)
+	if err != nil {
+		return err
+	}
+	pairing, err := algebra.GetPairing[sw_bn254.G1Affine, sw_bn254.G2Affine, sw_bn254.GTEl](api)
+	if err != nil {
+		return err
+	}
+	verifier := stdgroth16.NewVerifier[emulated.Element[emparams.BN254Fr]](curve, pairing, api)
+	return verifier.AssertProofs(c.VerifyingKey, c.Proofs, c.Witnesses)
+}
+
+// TestAssertProofsRoundTrip proves innerMulCircuit three times with distinct
+// witnesses using the real native backend, then batch-verifies all three
+// in-circuit. This exercises the real proofsChallenge Fiat-Shamir transcript
+// and the aggregated pairing check against genuine proofs, which
+// TestAssertProofsCompile's zero-valued placeholders never run the solver
+// against.
+func TestAssertProofsRoundTrip(t *testing.T) {
+	assert := test.NewAssert(t)
+
+	innerCcs, err := frontend.Compile(ecc.BN254.ScalarField(), r1cs.NewBuilder, &innerMulCircuit{})
+	assert.NoError(err)
+	innerPk, innerVk, err := groth16.Setup(innerCcs)
+	assert.NoError(err)
+
+	circuitVk, err := stdgroth16.ValueOfVerifyingKey[sw_bn254.G1Affine, sw_bn254.G2Affine, sw_bn254.GTEl](innerVk)
+	assert.NoError(err)
+
+	assignments := []*innerMulCircuit{
+		{A: 2, B: 3, N: 6},
+		{A: 4, B: 5, N: 20},
+		{A: 6, B: 7, N: 42},
+	}
+	proofs := make([]stdgroth16.Proof[sw_bn254.G1Affine, sw_bn254.G2Affine], len(assignments))
+	witnesses := make([]stdgroth16.Witness[emulated.Element[emparams.BN254Fr]], len(assignments))
+	for i, assignment := range assignments {
+		innerWitness, err := frontend.NewWitness(assignment, ecc.BN254.ScalarField())
+		assert.NoError(err)
+		innerPublicWitness, err := innerWitness.Public()
+		assert.NoError(err)
+
+		proof, err := groth16.Prove(innerCcs, innerPk, innerWitness)
+		assert.NoError(err)
+		assert.NoError(groth16.Verify(proof, innerVk, innerPublicWitness))
+
+		proofs[i], err = stdgroth16.ValueOfProof[sw_bn254.G1Affine, sw_bn254.G2Affine](proof)
+		assert.NoError(err)
+		witnesses[i], err = stdgroth16.ValueOfWitness[emulated.Element[emparams.BN254Fr], sw_bn254.G1Affine](innerWitness)
+		assert.NoError(err)
+	}
+
+	outerCircuit := &outerBatchVerifierCircuit{
+		VerifyingKey: stdgroth16.PlaceholderVerifyingKey[sw_bn254.G1Affine, sw_bn254.G2Affine, sw_bn254.GTEl](innerCcs),
+		Proofs:       make([]stdgroth16.Proof[sw_bn254.G1Affine, sw_bn254.G2Affine], len(assignments)),
+		Witnesses:    make([]stdgroth16.Witness[emulated.Element[emparams.BN254Fr]], len(assignments)),
+	}
+	for i := range outerCircuit.Witnesses {
+		outerCircuit.Witnesses[i] = stdgroth16.PlaceholderWitness[emulated.Element[emparams.BN254Fr]](innerCcs)
+	}
+	outerAssignment := &outerBatchVerifierCircuit{
+		VerifyingKey: circuitVk,
+		Proofs:       proofs,
+		Witnesses:    witnesses,
+	}
+	assert.SolvingSucceeded(outerCircuit, outerAssignment, test.WithCurves(ecc.BN254), test.NoFuzzing())
+}
@@ -0,0 +1,157 @@
+package groth16_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/backend/groth16"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/frontend/cs/r1cs"
+	"github.com/consensys/gnark/std/algebra"
+	"github.com/consensys/gnark/std/algebra/emulated/sw_bn254"
+	"github.com/consensys/gnark/std/math/emulated"
+	"github.com/consensys/gnark/std/math/emulated/emparams"
+	stdgroth16 "github.com/consensys/gnark/std/recursion/groth16"
+	"github.com/consensys/gnark/test"
+)
+
+// commitmentCircuit wires up [stdgroth16.Verifier.AssertProof] against a
+// placeholder VerifyingKey/Proof/Witness carrying nbCommitments Pedersen
+// commitments. It exists to catch generics and wiring mistakes at
+// circuit-compile time rather than only at proving time; it does not run the
+// solver, so it cannot tell whether the pairing relations it builds hold.
+type commitmentCircuit struct {
+	NbCommitments int
+	NbPublic      int
+}
+
+func (c *commitmentCircuit) Define(api frontend.API) error {
+	curve, err := algebra.GetCurve[emulated.Element[emparams.BN254Fr], sw_bn254.G1Affine](api)
+	if err != nil {
+		return err
+	}
+	pairing, err := algebra.GetPairing[sw_bn254.G1Affine, sw_bn254.G2Affine, sw_bn254.GTEl](api)
+	if err != nil {
+		return err
+	}
+	verifier := stdgroth16.NewVerifier[emulated.Element[emparams.BN254Fr]](curve, pairing, api)
+
+	var vk stdgroth16.VerifyingKey[sw_bn254.G1Affine, sw_bn254.G2Affine, sw_bn254.GTEl]
+	vk.G1.K = make([]sw_bn254.G1Affine, c.NbPublic+c.NbCommitments+1)
+	vk.G2.CommitmentKeys = make([]stdgroth16.CommitmentKey[sw_bn254.G2Affine], c.NbCommitments)
+
+	var proof stdgroth16.Proof[sw_bn254.G1Affine, sw_bn254.G2Affine]
+	proof.Commitments = make([]sw_bn254.G1Affine, c.NbCommitments)
+
+	witness := stdgroth16.Witness[emulated.Element[emparams.BN254Fr]]{
+		Public: make([]emulated.Element[emparams.BN254Fr], c.NbPublic),
+	}
+
+	return verifier.AssertProof(vk, proof, witness)
+}
+
+// TestAssertProofCommitmentsCompile checks that AssertProof builds a
+// constraint system both for a single commitment (the direct pairing check)
+// and for several (the folded multi-commitment check introduced to fix the
+// single-CommitmentPok bug). It only asserts the circuit compiles; the
+// multi-commitment case is not run against a genuine proof anywhere in this
+// package (TestAssertProofCommitmentRoundTrip uses a single commitment).
+func TestAssertProofCommitmentsCompile(t *testing.T) {
+	for _, nbCommitments := range []int{1, 3} {
+		circuit := &commitmentCircuit{NbCommitments: nbCommitments, NbPublic: 2}
+		if _, err := frontend.Compile(ecc.BN254.ScalarField(), r1cs.NewBuilder, circuit); err != nil {
+			t.Fatalf("compile with %d commitments: %v", nbCommitments, err)
+		}
+	}
+}
+
+// innerCommitmentCircuit commits to a secret input with api.Commit (a BSB22
+// Pedersen commitment), giving groth16.Prove something real to produce
+// proof.Commitments/proof.CommitmentPok from.
+type innerCommitmentCircuit struct {
+	Public frontend.Variable `gnark:",public"`
+	Secret frontend.Variable
+}
+
+func (c *innerCommitmentCircuit) Define(api frontend.API) error {
+	committer, ok := api.(frontend.Committer)
+	if !ok {
+		return fmt.Errorf("api %T does not implement frontend.Committer", api)
+	}
+	commitment, err := committer.Commit(c.Secret)
+	if err != nil {
+		return err
+	}
+	api.AssertIsDifferent(commitment, 0)
+	api.AssertIsEqual(c.Public, c.Public)
+	return nil
+}
+
+// outerCommitmentVerifierCircuit recursively verifies a genuine
+// innerCommitmentCircuit proof.
+type outerCommitmentVerifierCircuit struct {
+	VerifyingKey stdgroth16.VerifyingKey[sw_bn254.G1Affine, sw_bn254.G2Affine, sw_bn254.GTEl]
+	Proof        stdgroth16.Proof[sw_bn254.G1Affine, sw_bn254.G2Affine]
+	Witness      stdgroth16.Witness[emulated.Element[emparams.BN254Fr]]
+}
+
+func (c *outerCommitmentVerifierCircuit) Define(api frontend.API) error {
+	curve, err := algebra.GetCurve[emulated.Element[emparams.BN254Fr], sw_bn254.G1Affine](api)
+	if err != nil {
+		return err
+	}
+	pairing, err := algebra.GetPairing[sw_bn254.G1Affine, sw_bn254.G2Affine, sw_bn254.GTEl](api)
+	if err != nil {
+		return err
+	}
+	verifier := stdgroth16.NewVerifier[emulated.Element[emparams.BN254Fr]](curve, pairing, api)
+	return verifier.AssertProof(c.VerifyingKey, c.Proof, c.Witness)
+}
+
+// TestAssertProofCommitmentRoundTrip proves innerCommitmentCircuit with the
+// real native backend, then recursively verifies that proof in-circuit.
+// Unlike TestAssertProofCommitmentsCompile, frontend.Compile alone never
+// runs the solver: it would not confirm commitmentChallenge reproduces the
+// native backend's BSB22 Fiat-Shamir challenge bit-for-bit, nor that the
+// commitment proof of knowledge holds. Running the solver against a genuine
+// proof here exercises both. The inner circuit makes a single commitment, so
+// the multi-commitment fold of assertCommitmentPok is not exercised here.
+func TestAssertProofCommitmentRoundTrip(t *testing.T) {
+	assert := test.NewAssert(t)
+
+	innerCcs, err := frontend.Compile(ecc.BN254.ScalarField(), r1cs.NewBuilder, &innerCommitmentCircuit{})
+	assert.NoError(err)
+	innerPk, innerVk, err := groth16.Setup(innerCcs)
+	assert.NoError(err)
+
+	innerWitness, err := frontend.NewWitness(&innerCommitmentCircuit{Public: 3, Secret: 5}, ecc.BN254.ScalarField())
+	assert.NoError(err)
+	innerPublicWitness, err := innerWitness.Public()
+	assert.NoError(err)
+
+	proof, err := groth16.Prove(innerCcs, innerPk, innerWitness)
+	assert.NoError(err)
+	assert.NoError(groth16.Verify(proof, innerVk, innerPublicWitness))
+
+	circuitVk, err := stdgroth16.ValueOfVerifyingKey[sw_bn254.G1Affine, sw_bn254.G2Affine, sw_bn254.GTEl](innerVk)
+	assert.NoError(err)
+	circuitProof, err := stdgroth16.ValueOfProof[sw_bn254.G1Affine, sw_bn254.G2Affine](proof)
+	assert.NoError(err)
+	circuitWitness, err := stdgroth16.ValueOfWitness[emulated.Element[emparams.BN254Fr], sw_bn254.G1Affine](innerWitness)
+	assert.NoError(err)
+
+	outerCircuit := &outerCommitmentVerifierCircuit{
+		VerifyingKey: stdgroth16.PlaceholderVerifyingKey[sw_bn254.G1Affine, sw_bn254.G2Affine, sw_bn254.GTEl](innerCcs),
+		Proof: stdgroth16.Proof[sw_bn254.G1Affine, sw_bn254.G2Affine]{
+			Commitments: make([]sw_bn254.G1Affine, len(circuitProof.Commitments)),
+		},
+		Witness: stdgroth16.PlaceholderWitness[emulated.Element[emparams.BN254Fr]](innerCcs),
+	}
+	outerAssignment := &outerCommitmentVerifierCircuit{
+		VerifyingKey: circuitVk,
+		Proof:        circuitProof,
+		Witness:      circuitWitness,
+	}
+	assert.SolvingSucceeded(outerCircuit, outerAssignment, test.WithCurves(ecc.BN254), test.NoFuzzing())
+}
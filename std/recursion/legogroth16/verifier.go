@@ -0,0 +1,334 @@
+// Package legogroth16 implements native verification of LegoGroth16 proofs in
+// a SNARK circuit.
+//
+// LegoGroth16 extends Groth16 with a Pedersen-style commitment D to a prefix
+// of the witness, plus a CP_link subspace-SNARK proof that links D to the
+// Groth16 commitment already baked into the proof's Ar/Krs elements (see
+// [github.com/consensys/gnark/std/recursion/groth16] for the plain verifier
+// this package builds on).
+package legogroth16
+
+import (
+	"fmt"
+
+	bls12377 "github.com/consensys/gnark-crypto/ecc/bls12-377"
+	bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	bls24315 "github.com/consensys/gnark-crypto/ecc/bls24-315"
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	groth16backend "github.com/consensys/gnark/backend/groth16"
+	"github.com/consensys/gnark/backend/witness"
+	"github.com/consensys/gnark/constraint"
+	"github.com/consensys/gnark/std/algebra"
+	"github.com/consensys/gnark/std/algebra/emulated/sw_bls12381"
+	"github.com/consensys/gnark/std/algebra/emulated/sw_bn254"
+	"github.com/consensys/gnark/std/algebra/native/sw_bls12377"
+	"github.com/consensys/gnark/std/algebra/native/sw_bls24315"
+	"github.com/consensys/gnark/std/recursion/groth16"
+)
+
+// Proof is a typed LegoGroth16 proof of SNARK. Use [ValueOfProof] to
+// initialize the witness from the native proof.
+type Proof[G1El algebra.G1ElementT, G2El algebra.G2ElementT] struct {
+	groth16.Proof[G1El, G2El]
+
+	// D is the Pedersen commitment to the committed prefix of the witness,
+	// i.e. D = Σ vᵢ·hᵢ + link_v·h_{ℓ+1}.
+	D G1El
+	// PiLink is the CP_link subspace-SNARK proof π_ℓ = (π1, π2) that D
+	// commits to the same values as the Groth16 commitment baked into Ar/Krs.
+	PiLink struct{ Pi1, Pi2 G1El }
+}
+
+// ValueOfProof returns the typed witness of the native proof. d, piLink1 and
+// piLink2 must be the native curve points matching the type parameters (e.g.
+// [bn254.G1Affine] for [Proof][sw_bn254.G1Affine, sw_bn254.G2Affine]), as
+// there is no dedicated LegoGroth16 backend in this module to type them for
+// us. It returns an error if there is a mismatch between the type parameters
+// and the provided values.
+func ValueOfProof[G1El algebra.G1ElementT, G2El algebra.G2ElementT](proof groth16backend.Proof, d, piLink1, piLink2 any) (Proof[G1El, G2El], error) {
+	innerProof, err := groth16.ValueOfProof[G1El, G2El](proof)
+	if err != nil {
+		return Proof[G1El, G2El]{}, fmt.Errorf("inner groth16 proof: %w", err)
+	}
+	ret := Proof[G1El, G2El]{Proof: innerProof}
+	switch ar := any(&ret).(type) {
+	case *Proof[sw_bn254.G1Affine, sw_bn254.G2Affine]:
+		dd, ok := d.(bn254.G1Affine)
+		if !ok {
+			return ret, fmt.Errorf("expected bn254.G1Affine, got %T", d)
+		}
+		p1, ok := piLink1.(bn254.G1Affine)
+		if !ok {
+			return ret, fmt.Errorf("expected bn254.G1Affine, got %T", piLink1)
+		}
+		p2, ok := piLink2.(bn254.G1Affine)
+		if !ok {
+			return ret, fmt.Errorf("expected bn254.G1Affine, got %T", piLink2)
+		}
+		ar.D = sw_bn254.NewG1Affine(dd)
+		ar.PiLink.Pi1 = sw_bn254.NewG1Affine(p1)
+		ar.PiLink.Pi2 = sw_bn254.NewG1Affine(p2)
+	case *Proof[sw_bls12377.G1Affine, sw_bls12377.G2Affine]:
+		dd, ok := d.(bls12377.G1Affine)
+		if !ok {
+			return ret, fmt.Errorf("expected bls12377.G1Affine, got %T", d)
+		}
+		p1, ok := piLink1.(bls12377.G1Affine)
+		if !ok {
+			return ret, fmt.Errorf("expected bls12377.G1Affine, got %T", piLink1)
+		}
+		p2, ok := piLink2.(bls12377.G1Affine)
+		if !ok {
+			return ret, fmt.Errorf("expected bls12377.G1Affine, got %T", piLink2)
+		}
+		ar.D = sw_bls12377.NewG1Affine(dd)
+		ar.PiLink.Pi1 = sw_bls12377.NewG1Affine(p1)
+		ar.PiLink.Pi2 = sw_bls12377.NewG1Affine(p2)
+	case *Proof[sw_bls12381.G1Affine, sw_bls12381.G2Affine]:
+		dd, ok := d.(bls12381.G1Affine)
+		if !ok {
+			return ret, fmt.Errorf("expected bls12381.G1Affine, got %T", d)
+		}
+		p1, ok := piLink1.(bls12381.G1Affine)
+		if !ok {
+			return ret, fmt.Errorf("expected bls12381.G1Affine, got %T", piLink1)
+		}
+		p2, ok := piLink2.(bls12381.G1Affine)
+		if !ok {
+			return ret, fmt.Errorf("expected bls12381.G1Affine, got %T", piLink2)
+		}
+		ar.D = sw_bls12381.NewG1Affine(dd)
+		ar.PiLink.Pi1 = sw_bls12381.NewG1Affine(p1)
+		ar.PiLink.Pi2 = sw_bls12381.NewG1Affine(p2)
+	case *Proof[sw_bls24315.G1Affine, sw_bls24315.G2Affine]:
+		dd, ok := d.(bls24315.G1Affine)
+		if !ok {
+			return ret, fmt.Errorf("expected bls24315.G1Affine, got %T", d)
+		}
+		p1, ok := piLink1.(bls24315.G1Affine)
+		if !ok {
+			return ret, fmt.Errorf("expected bls24315.G1Affine, got %T", piLink1)
+		}
+		p2, ok := piLink2.(bls24315.G1Affine)
+		if !ok {
+			return ret, fmt.Errorf("expected bls24315.G1Affine, got %T", piLink2)
+		}
+		ar.D = sw_bls24315.NewG1Affine(dd)
+		ar.PiLink.Pi1 = sw_bls24315.NewG1Affine(p1)
+		ar.PiLink.Pi2 = sw_bls24315.NewG1Affine(p2)
+	default:
+		return ret, fmt.Errorf("unknown parametric type combination")
+	}
+	return ret, nil
+}
+
+// VerifyingKey is a typed LegoGroth16 verifying key for checking SNARK
+// proofs. For witness creation use the method [ValueOfVerifyingKey] and for
+// stub placeholder use [PlaceholderVerifyingKey].
+type VerifyingKey[G1El algebra.G1ElementT, G2El algebra.G2ElementT, GtEl algebra.GtElementT] struct {
+	groth16.VerifyingKey[G1El, G2El, GtEl]
+
+	// Link holds the CP_link subspace-SNARK verifying key material.
+	Link struct {
+		H         []G1El // Pedersen bases {hᵢ} for the committed witness prefix, plus h_{ℓ+1} for link_v
+		P, PPrime G2El   // fixed G2 elements describing the linear subspace
+		G2Gen     G2El   // G2 generator the subspace equality is checked against
+	}
+}
+
+// PlaceholderVerifyingKey returns an empty verifying key for a given compiled
+// constraint system. nbLinked is the size of the committed witness prefix
+// (not counting the link randomizer h_{ℓ+1}, which is allocated implicitly).
+func PlaceholderVerifyingKey[G1El algebra.G1ElementT, G2El algebra.G2ElementT, GtEl algebra.GtElementT](ccs constraint.ConstraintSystem, nbLinked int) VerifyingKey[G1El, G2El, GtEl] {
+	ret := VerifyingKey[G1El, G2El, GtEl]{VerifyingKey: groth16.PlaceholderVerifyingKey[G1El, G2El, GtEl](ccs)}
+	ret.Link.H = make([]G1El, nbLinked+1)
+	return ret
+}
+
+// ValueOfVerifyingKey initializes witness from the given native Groth16
+// verifying key and the CP_link parameters h, p, pPrime and g2Gen. As there is
+// no dedicated LegoGroth16 backend in this module, the CP_link parameters
+// must be passed as the native curve points matching the type parameters
+// (e.g. [bn254.G1Affine] and [bn254.G2Affine]). It returns an error if there
+// is a mismatch between the type parameters and the provided values.
+func ValueOfVerifyingKey[G1El algebra.G1ElementT, G2El algebra.G2ElementT, GtEl algebra.GtElementT](vk groth16backend.VerifyingKey, h []any, p, pPrime, g2Gen any) (VerifyingKey[G1El, G2El, GtEl], error) {
+	innerVk, err := groth16.ValueOfVerifyingKey[G1El, G2El, GtEl](vk)
+	if err != nil {
+		return VerifyingKey[G1El, G2El, GtEl]{}, fmt.Errorf("inner groth16 verifying key: %w", err)
+	}
+	ret := VerifyingKey[G1El, G2El, GtEl]{VerifyingKey: innerVk}
+	switch s := any(&ret).(type) {
+	case *VerifyingKey[sw_bn254.G1Affine, sw_bn254.G2Affine, sw_bn254.GTEl]:
+		s.Link.H = make([]sw_bn254.G1Affine, len(h))
+		for i := range h {
+			hh, ok := h[i].(bn254.G1Affine)
+			if !ok {
+				return ret, fmt.Errorf("expected bn254.G1Affine, got %T", h[i])
+			}
+			s.Link.H[i] = sw_bn254.NewG1Affine(hh)
+		}
+		pp, ok := p.(bn254.G2Affine)
+		if !ok {
+			return ret, fmt.Errorf("expected bn254.G2Affine, got %T", p)
+		}
+		ppPrime, ok := pPrime.(bn254.G2Affine)
+		if !ok {
+			return ret, fmt.Errorf("expected bn254.G2Affine, got %T", pPrime)
+		}
+		gg, ok := g2Gen.(bn254.G2Affine)
+		if !ok {
+			return ret, fmt.Errorf("expected bn254.G2Affine, got %T", g2Gen)
+		}
+		s.Link.P = sw_bn254.NewG2Affine(pp)
+		s.Link.PPrime = sw_bn254.NewG2Affine(ppPrime)
+		s.Link.G2Gen = sw_bn254.NewG2Affine(gg)
+	case *VerifyingKey[sw_bls12377.G1Affine, sw_bls12377.G2Affine, sw_bls12377.GT]:
+		s.Link.H = make([]sw_bls12377.G1Affine, len(h))
+		for i := range h {
+			hh, ok := h[i].(bls12377.G1Affine)
+			if !ok {
+				return ret, fmt.Errorf("expected bls12377.G1Affine, got %T", h[i])
+			}
+			s.Link.H[i] = sw_bls12377.NewG1Affine(hh)
+		}
+		pp, ok := p.(bls12377.G2Affine)
+		if !ok {
+			return ret, fmt.Errorf("expected bls12377.G2Affine, got %T", p)
+		}
+		ppPrime, ok := pPrime.(bls12377.G2Affine)
+		if !ok {
+			return ret, fmt.Errorf("expected bls12377.G2Affine, got %T", pPrime)
+		}
+		gg, ok := g2Gen.(bls12377.G2Affine)
+		if !ok {
+			return ret, fmt.Errorf("expected bls12377.G2Affine, got %T", g2Gen)
+		}
+		s.Link.P = sw_bls12377.NewG2Affine(pp)
+		s.Link.PPrime = sw_bls12377.NewG2Affine(ppPrime)
+		s.Link.G2Gen = sw_bls12377.NewG2Affine(gg)
+	case *VerifyingKey[sw_bls12381.G1Affine, sw_bls12381.G2Affine, sw_bls12381.GTEl]:
+		s.Link.H = make([]sw_bls12381.G1Affine, len(h))
+		for i := range h {
+			hh, ok := h[i].(bls12381.G1Affine)
+			if !ok {
+				return ret, fmt.Errorf("expected bls12381.G1Affine, got %T", h[i])
+			}
+			s.Link.H[i] = sw_bls12381.NewG1Affine(hh)
+		}
+		pp, ok := p.(bls12381.G2Affine)
+		if !ok {
+			return ret, fmt.Errorf("expected bls12381.G2Affine, got %T", p)
+		}
+		ppPrime, ok := pPrime.(bls12381.G2Affine)
+		if !ok {
+			return ret, fmt.Errorf("expected bls12381.G2Affine, got %T", pPrime)
+		}
+		gg, ok := g2Gen.(bls12381.G2Affine)
+		if !ok {
+			return ret, fmt.Errorf("expected bls12381.G2Affine, got %T", g2Gen)
+		}
+		s.Link.P = sw_bls12381.NewG2Affine(pp)
+		s.Link.PPrime = sw_bls12381.NewG2Affine(ppPrime)
+		s.Link.G2Gen = sw_bls12381.NewG2Affine(gg)
+	case *VerifyingKey[sw_bls24315.G1Affine, sw_bls24315.G2Affine, sw_bls24315.GT]:
+		s.Link.H = make([]sw_bls24315.G1Affine, len(h))
+		for i := range h {
+			hh, ok := h[i].(bls24315.G1Affine)
+			if !ok {
+				return ret, fmt.Errorf("expected bls24315.G1Affine, got %T", h[i])
+			}
+			s.Link.H[i] = sw_bls24315.NewG1Affine(hh)
+		}
+		pp, ok := p.(bls24315.G2Affine)
+		if !ok {
+			return ret, fmt.Errorf("expected bls24315.G2Affine, got %T", p)
+		}
+		ppPrime, ok := pPrime.(bls24315.G2Affine)
+		if !ok {
+			return ret, fmt.Errorf("expected bls24315.G2Affine, got %T", pPrime)
+		}
+		gg, ok := g2Gen.(bls24315.G2Affine)
+		if !ok {
+			return ret, fmt.Errorf("expected bls24315.G2Affine, got %T", g2Gen)
+		}
+		s.Link.P = sw_bls24315.NewG2Affine(pp)
+		s.Link.PPrime = sw_bls24315.NewG2Affine(ppPrime)
+		s.Link.G2Gen = sw_bls24315.NewG2Affine(gg)
+	default:
+		return ret, fmt.Errorf("unknown parametric type combination")
+	}
+	return ret, nil
+}
+
+// Witness is a public witness to verify the SNARK proof against. For
+// assigning witness use [ValueOfWitness] and to create stub witness for
+// compiling use [PlaceholderWitness].
+type Witness[S algebra.ScalarT] = groth16.Witness[S]
+
+// PlaceholderWitness creates a stub witness which can be used to allocate the
+// variables in the circuit if the actual witness is not yet known.
+func PlaceholderWitness[S algebra.ScalarT](ccs constraint.ConstraintSystem) Witness[S] {
+	return groth16.PlaceholderWitness[S](ccs)
+}
+
+// ValueOfWitness assigns a outer-circuit witness from the inner circuit
+// witness. It returns an error if there is a mismatch between the type
+// parameters and the provided native witness.
+func ValueOfWitness[S algebra.ScalarT, G1 algebra.G1ElementT](w witness.Witness) (Witness[S], error) {
+	return groth16.ValueOfWitness[S, G1](w)
+}
+
+// Verifier verifies LegoGroth16 proofs.
+type Verifier[S algebra.ScalarT, G1El algebra.G1ElementT, G2El algebra.G2ElementT, GtEl algebra.GtElementT] struct {
+	curve   algebra.Curve[S, G1El]
+	pairing algebra.Pairing[G1El, G2El, GtEl]
+}
+
+// NewVerifier returns a new [Verifier] instance using the curve and pairing
+// interfaces. Use methods [algebra.GetCurve] and [algebra.GetPairing] to
+// initialize the instances.
+func NewVerifier[S algebra.ScalarT, G1El algebra.G1ElementT, G2El algebra.G2ElementT, GtEl algebra.GtElementT](curve algebra.Curve[S, G1El], pairing algebra.Pairing[G1El, G2El, GtEl]) *Verifier[S, G1El, G2El, GtEl] {
+	return &Verifier[S, G1El, G2El, GtEl]{
+		curve:   curve,
+		pairing: pairing,
+	}
+}
+
+// AssertProof asserts that the LegoGroth16 proof holds for the given witness
+// and verifying key. Unlike a BSB22 Groth16 commitment, D is not bound to a
+// Fiat-Shamir challenge nor checked against a proof of knowledge here: it is
+// folded into kSum with weight one, and its opening is instead attested by
+// the CP_link subspace relation e(D, P)·e(π1, P') = e(π2, g2) enforced below.
+func (v *Verifier[S, G1El, G2El, GtEl]) AssertProof(vk VerifyingKey[G1El, G2El, GtEl], proof Proof[G1El, G2El], witness Witness[S]) error {
+	if len(vk.G1.K) != len(witness.Public)+1 {
+		return fmt.Errorf("vk has %d public inputs, but witness has %d", len(vk.G1.K)-1, len(witness.Public))
+	}
+	inP := make([]*G1El, len(vk.G1.K)-1)
+	for i := range inP {
+		inP[i] = &vk.G1.K[i+1]
+	}
+	inS := make([]*S, len(witness.Public))
+	for i := range witness.Public {
+		inS[i] = &witness.Public[i]
+	}
+	kSum, err := v.curve.MultiScalarMul(inP, inS)
+	if err != nil {
+		return fmt.Errorf("multi scalar mul: %w", err)
+	}
+	kSum = v.curve.Add(kSum, &vk.G1.K[0])
+	kSum = v.curve.Add(kSum, &proof.D)
+	pairing, err := v.pairing.Pair([]*G1El{kSum, &proof.Krs, &proof.Ar}, []*G2El{&vk.G2.GammaNeg, &vk.G2.DeltaNeg, &proof.Bs})
+	if err != nil {
+		return fmt.Errorf("pairing: %w", err)
+	}
+	v.pairing.AssertIsEqual(pairing, &vk.E)
+
+	negPi2 := v.curve.Neg(&proof.PiLink.Pi2)
+	if err := v.pairing.PairingCheck(
+		[]*G1El{&proof.D, &proof.PiLink.Pi1, negPi2},
+		[]*G2El{&vk.Link.P, &vk.Link.PPrime, &vk.Link.G2Gen},
+	); err != nil {
+		return fmt.Errorf("CP_link subspace check: %w", err)
+	}
+	return nil
+}
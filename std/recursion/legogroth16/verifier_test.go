@@ -0,0 +1,60 @@
+package legogroth16_test
+
+import (
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/frontend/cs/r1cs"
+	"github.com/consensys/gnark/std/algebra"
+	"github.com/consensys/gnark/std/algebra/emulated/sw_bn254"
+	"github.com/consensys/gnark/std/math/emulated"
+	"github.com/consensys/gnark/std/math/emulated/emparams"
+	stdlegogroth16 "github.com/consensys/gnark/std/recursion/legogroth16"
+)
+
+// verifierCircuit wires up [stdlegogroth16.Verifier.AssertProof] against a
+// placeholder VerifyingKey/Proof/Witness, to catch wiring mistakes in the
+// dedicated kSum fold (D added with weight one, no Fiat-Shamir challenge, no
+// BSB22 proof of knowledge) and in the CP_link subspace check.
+type verifierCircuit struct {
+	NbPublic int
+}
+
+func (c *verifierCircuit) Define(api frontend.API) error {
+	curve, err := algebra.GetCurve[emulated.Element[emparams.BN254Fr], sw_bn254.G1Affine](api)
+	if err != nil {
+		return err
+	}
+	pairing, err := algebra.GetPairing[sw_bn254.G1Affine, sw_bn254.G2Affine, sw_bn254.GTEl](api)
+	if err != nil {
+		return err
+	}
+	verifier := stdlegogroth16.NewVerifier[emulated.Element[emparams.BN254Fr]](curve, pairing)
+
+	var vk stdlegogroth16.VerifyingKey[sw_bn254.G1Affine, sw_bn254.G2Affine, sw_bn254.GTEl]
+	vk.G1.K = make([]sw_bn254.G1Affine, c.NbPublic+1)
+	vk.Link.H = make([]sw_bn254.G1Affine, 1)
+
+	var proof stdlegogroth16.Proof[sw_bn254.G1Affine, sw_bn254.G2Affine]
+
+	witness := stdlegogroth16.Witness[emulated.Element[emparams.BN254Fr]]{
+		Public: make([]emulated.Element[emparams.BN254Fr], c.NbPublic),
+	}
+
+	return verifier.AssertProof(vk, proof, witness)
+}
+
+// TestAssertProofCompile checks that AssertProof builds a constraint system.
+// Exercising it against a genuine LegoGroth16 proof would need a native
+// prover for the scheme to produce a real D/CP_link pair from; no such
+// prover exists in gnark or this module (LegoGroth16 support here is
+// verify-only), so unlike the plain Groth16 verifier next door this package
+// cannot get a real solver round-trip test and is limited to this
+// compile-time wiring check.
+func TestAssertProofCompile(t *testing.T) {
+	circuit := &verifierCircuit{NbPublic: 2}
+	if _, err := frontend.Compile(ecc.BN254.ScalarField(), r1cs.NewBuilder, circuit); err != nil {
+		t.Fatalf("compile: %v", err)
+	}
+}